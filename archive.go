@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// -archive, scrapeOne'ın ürettiği site_data.html'in yanına, tamamen
+// kendi kendine yeten (offline açılabilen) bir kopya üretir:
+// output/<slug>/archive/ altında görseller, stylesheet'ler ve script'ler
+// indirilip assets/ içine SHA1 tabanlı adlarla kaydedilir, HTML'deki
+// referanslar bu yerel dosyalara işaret edecek şekilde yeniden yazılır.
+//
+// -archive-format warc verilirse, aynı indirme sonuçları yerine tek bir
+// WARC 1.1 dosyasına (request+response çiftleri olarak) yazılır; böylece
+// pywb/wayback gibi araçlarla uyumlu olur.
+
+var cssURLPattern = regexp.MustCompile(`url\(\s*['"]?([^'")]+)['"]?\s*\)`)
+
+type archiveAssetResult struct {
+	URL         string `json:"url"`
+	LocalPath   string `json:"local_path,omitempty"`
+	Bytes       int    `json:"bytes"`
+	ContentType string `json:"content_type,omitempty"`
+	HTTPStatus  int    `json:"http_status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// archivePage, bir sayfanın zaten fetchHTML ile indirilmiş body'sini alıp
+// -archive-format'a göre ya dosya tabanlı bir snapshot ya da tek bir WARC
+// dosyası üretir.
+func archivePage(baseURL *url.URL, body []byte, outDir string, opts siteOptions) error {
+	if opts.ArchiveFormat == "warc" {
+		return archiveAsWARC(baseURL, body, outDir, opts)
+	}
+	return archiveAsFiles(baseURL, body, outDir, opts)
+}
+
+func archiveAsFiles(baseURL *url.URL, body []byte, outDir string, opts siteOptions) error {
+	archiveDir := filepath.Join(outDir, "archive")
+	assetsDir := filepath.Join(archiveDir, "assets")
+	if err := os.MkdirAll(assetsDir, 0o755); err != nil {
+		return fmt.Errorf("archive klasörü oluşturulamadı: %v", err)
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("HTML parse edilemedi: %v", err)
+	}
+
+	refs := collectAssetRefs(doc, baseURL)
+
+	limiter := newHostRateLimiter(opts.RateLimitDelay)
+	sem := make(chan struct{}, 6)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	localPaths := make(map[string]string) // absolute asset URL -> assets/<file> göreli yol
+	manifest := make([]archiveAssetResult, 0, len(refs))
+
+	for _, ref := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(assetURL string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			limiter.wait(assetURL)
+			result := downloadAsset(assetURL, assetsDir)
+
+			// rewriteCSSAssets kendi içinde her url(...) referansı için ayrıca
+			// ağ indirmesi yapıyor; bunu mu altında çağırmak, tüm sem havuzunu
+			// tek bir CSS dosyası bitene kadar kilitler. Manifest/localPaths'e
+			// yazmadan önce, kilit dışındayken çalıştırıyoruz.
+			if result.LocalPath != "" && isCSS(result.ContentType, assetURL) {
+				rewriteCSSAssets(filepath.Join(assetsDir, result.LocalPath), assetURL, assetsDir, limiter)
+			}
+
+			mu.Lock()
+			manifest = append(manifest, result)
+			if result.LocalPath != "" {
+				localPaths[assetURL] = result.LocalPath
+			}
+			mu.Unlock()
+		}(ref.absoluteURL)
+	}
+	wg.Wait()
+
+	for _, ref := range refs {
+		if local, ok := localPaths[ref.absoluteURL]; ok {
+			ref.node.Attr[ref.attrIndex].Val = "assets/" + local
+		}
+	}
+
+	var rendered bytes.Buffer
+	if err := html.Render(&rendered, doc); err != nil {
+		return fmt.Errorf("HTML yeniden yazılamadı: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(archiveDir, "index.html"), rendered.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("archive/index.html yazılamadı: %v", err)
+	}
+
+	if err := writeJSON(filepath.Join(archiveDir, "manifest.json"), manifest); err != nil {
+		return fmt.Errorf("manifest.json yazılamadı: %v", err)
+	}
+	return nil
+}
+
+// assetRef, DOM'daki tek bir indirilebilir referanstır (attrIndex, node.Attr
+// içindeki slice pozisyonu: indirme bitince değeri yerel yolla değiştirilir).
+type assetRef struct {
+	node        *html.Node
+	attrIndex   int
+	absoluteURL string
+}
+
+func collectAssetRefs(doc *html.Node, baseURL *url.URL) []assetRef {
+	var refs []assetRef
+
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "img", "script":
+				if idx := findAttr(n, "src"); idx >= 0 {
+					if abs := resolveAssetURL(baseURL, n.Attr[idx].Val); abs != "" {
+						refs = append(refs, assetRef{node: n, attrIndex: idx, absoluteURL: abs})
+					}
+				}
+			case "link":
+				if relIdx := findAttr(n, "rel"); relIdx >= 0 && strings.EqualFold(n.Attr[relIdx].Val, "stylesheet") {
+					if idx := findAttr(n, "href"); idx >= 0 {
+						if abs := resolveAssetURL(baseURL, n.Attr[idx].Val); abs != "" {
+							refs = append(refs, assetRef{node: n, attrIndex: idx, absoluteURL: abs})
+						}
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	visit(doc)
+	return refs
+}
+
+func findAttr(n *html.Node, key string) int {
+	for i, a := range n.Attr {
+		if a.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func resolveAssetURL(baseURL *url.URL, raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "data:") {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return baseURL.ResolveReference(u).String()
+}
+
+// downloadAsset, tek bir asset'i indirip assets/ altına SHA1(url) tabanlı bir
+// dosya adıyla kaydeder ve manifest için sonucu döner.
+func downloadAsset(assetURL, assetsDir string) archiveAssetResult {
+	res := archiveAssetResult{URL: assetURL}
+
+	req, err := http.NewRequest(http.MethodGet, assetURL, nil)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	req.Header.Set("User-Agent", "webscraper-go/1.0 (+https://example.com)")
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+	res.HTTPStatus = resp.StatusCode
+	res.ContentType = resp.Header.Get("Content-Type")
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Bytes = buf.Len()
+
+	if resp.StatusCode >= 400 {
+		res.Error = fmt.Sprintf("HTTP hata: %s", resp.Status)
+		return res
+	}
+
+	filename := assetFilename(assetURL, res.ContentType)
+	if err := os.WriteFile(filepath.Join(assetsDir, filename), buf.Bytes(), 0o644); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.LocalPath = filename
+	return res
+}
+
+func assetFilename(assetURL, contentType string) string {
+	h := sha1.Sum([]byte(assetURL))
+	short := hex.EncodeToString(h[:])
+
+	ext := path.Ext(strings.SplitN(assetURL, "?", 2)[0])
+	if ext == "" || len(ext) > 8 {
+		switch {
+		case strings.Contains(contentType, "css"):
+			ext = ".css"
+		case strings.Contains(contentType, "javascript"):
+			ext = ".js"
+		case strings.Contains(contentType, "png"):
+			ext = ".png"
+		case strings.Contains(contentType, "jpeg"):
+			ext = ".jpg"
+		case strings.Contains(contentType, "gif"):
+			ext = ".gif"
+		case strings.Contains(contentType, "svg"):
+			ext = ".svg"
+		default:
+			ext = ".bin"
+		}
+	}
+	return short + ext
+}
+
+func isCSS(contentType, assetURL string) bool {
+	return strings.Contains(contentType, "css") || strings.HasSuffix(strings.SplitN(assetURL, "?", 2)[0], ".css")
+}
+
+// rewriteCSSAssets, indirilmiş bir CSS dosyasındaki url(...) referanslarını
+// (arkaplan resimleri, @font-face vb.) indirip dosya içindeki referansları
+// yerel assets/ yollarıyla değiştirir.
+func rewriteCSSAssets(cssPath, cssURL, assetsDir string, limiter *hostRateLimiter) {
+	base, err := url.Parse(cssURL)
+	if err != nil {
+		return
+	}
+	content, err := os.ReadFile(cssPath)
+	if err != nil {
+		return
+	}
+
+	replaced := cssURLPattern.ReplaceAllFunc(content, func(m []byte) []byte {
+		sub := cssURLPattern.FindSubmatch(m)
+		if len(sub) < 2 {
+			return m
+		}
+		abs := resolveAssetURL(base, string(sub[1]))
+		if abs == "" {
+			return m
+		}
+		limiter.wait(abs)
+		result := downloadAsset(abs, assetsDir)
+		if result.LocalPath == "" {
+			return m
+		}
+		return []byte(fmt.Sprintf("url(%s)", result.LocalPath))
+	})
+
+	_ = os.WriteFile(cssPath, replaced, 0o644)
+}
+
+// hostRateLimiter, aynı host'a ardışık istekler arasında en az `delay` kadar
+// bekleyen basit bir kısıtlayıcıdır; archive sırasında bir siteyi asset
+// indirirken bombalamamak için.
+type hostRateLimiter struct {
+	delay time.Duration
+	mu    sync.Mutex
+	last  map[string]time.Time
+}
+
+func newHostRateLimiter(delay time.Duration) *hostRateLimiter {
+	return &hostRateLimiter{delay: delay, last: make(map[string]time.Time)}
+}
+
+func (l *hostRateLimiter) wait(rawURL string) {
+	if l.delay <= 0 {
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	prev, ok := l.last[u.Host]
+	wait := time.Duration(0)
+	if ok {
+		if elapsed := time.Since(prev); elapsed < l.delay {
+			wait = l.delay - elapsed
+		}
+	}
+	l.last[u.Host] = time.Now().Add(wait)
+	l.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
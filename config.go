@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// -config config.yaml ile verilen, "defaults" + "sites" bloklarından oluşan
+// katmanlı yapılandırma. Öncelik sırası: komut satırı bayrağı (açıkça
+// verildiyse) > ortam değişkeni > config dosyası > kod içindeki built-in
+// varsayılan. 15 URL'lik listeyi elle düzenlemek yerine kullanıcıların
+// büyütebileceği doğal yol burası.
+
+// CookieConfig, bir site için scrapeOne'dan önce ayarlanacak tek bir
+// cookie'yi tanımlar.
+type CookieConfig struct {
+	Name  string `yaml:"name" json:"name"`
+	Value string `yaml:"value" json:"value"`
+}
+
+// DefaultsConfig, config dosyasındaki "defaults" bloğudur; sites listesinde
+// override edilmeyen her şey buradan gelir.
+type DefaultsConfig struct {
+	TimeoutSec     int    `yaml:"timeout_seconds" json:"timeout_seconds"`
+	UserAgent      string `yaml:"user_agent" json:"user_agent"`
+	Screenshot     *bool  `yaml:"screenshot" json:"screenshot"`
+	OutputRoot     string `yaml:"output_root" json:"output_root"`
+	Concurrency    int    `yaml:"concurrency" json:"concurrency"`
+	RateLimitDelay string `yaml:"rate_limit_delay" json:"rate_limit_delay"`
+}
+
+// SiteConfig, "sites" listesindeki tek bir URL için override'lardır.
+type SiteConfig struct {
+	URL            string            `yaml:"url" json:"url"`
+	Headers        map[string]string `yaml:"headers" json:"headers"`
+	Cookies        []CookieConfig    `yaml:"cookies" json:"cookies"`
+	WaitSelector   string            `yaml:"wait_selector" json:"wait_selector"`
+	ExtractLinks   *bool             `yaml:"extract_links" json:"extract_links"`
+	PostFetchJS    string            `yaml:"post_fetch_js" json:"post_fetch_js"`
+	RateLimitDelay string            `yaml:"rate_limit_delay" json:"rate_limit_delay"`
+}
+
+// Config, -config dosyasının tamamıdır.
+type Config struct {
+	Defaults DefaultsConfig `yaml:"defaults" json:"defaults"`
+	Sites    []SiteConfig   `yaml:"sites" json:"sites"`
+}
+
+// loadConfig, uzantısına göre (.yaml/.yml -> YAML, diğerleri -> JSON) config
+// dosyasını okur.
+func loadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config dosyası okunamadı: %v", err)
+	}
+
+	cfg := &Config{}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		if err := yaml.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("config YAML parse edilemedi: %v", err)
+		}
+	} else {
+		if err := json.Unmarshal(b, cfg); err != nil {
+			return nil, fmt.Errorf("config JSON parse edilemedi: %v", err)
+		}
+	}
+	return cfg, nil
+}
+
+func (c *Config) siteFor(rawURL string) *SiteConfig {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Sites {
+		if c.Sites[i].URL == rawURL {
+			return &c.Sites[i]
+		}
+	}
+	return nil
+}
+
+// siteOptions, tek bir URL'nin scrapeOne/fetchHTML/takeScreenshot boyunca
+// kullanacağı, tüm katmanlardan (flag/env/config/built-in) çözülmüş nihai
+// ayarlardır.
+type siteOptions struct {
+	Timeout        time.Duration
+	UserAgent      string
+	Screenshot     bool
+	Headers        map[string]string
+	Cookies        []CookieConfig
+	WaitSelector   string
+	ExtractLinks   bool
+	PostFetchJS    string
+	RateLimitDelay time.Duration
+	Archive        bool
+	ArchiveFormat  string
+}
+
+// runSettings, flag/env/config birleşiminden gelen ve her URL için ortak
+// olan (site-spesifik olmayan) ayarlardır.
+type runSettings struct {
+	cfg           *Config
+	explicitFlags map[string]bool
+
+	flagTimeoutSec    int
+	flagNoShot        bool
+	flagArchive       bool
+	flagArchiveFormat string
+}
+
+func newRunSettings(cfg *Config, explicitFlags map[string]bool, flagTimeoutSec int, flagNoShot, flagArchive bool, flagArchiveFormat string) *runSettings {
+	return &runSettings{
+		cfg:               cfg,
+		explicitFlags:     explicitFlags,
+		flagTimeoutSec:    flagTimeoutSec,
+		flagNoShot:        flagNoShot,
+		flagArchive:       flagArchive,
+		flagArchiveFormat: flagArchiveFormat,
+	}
+}
+
+// resolve, tek bir URL için nihai siteOptions'ı üretir: flag (açıkça
+// verildiyse) > env > config dosyası (sites override'ı, sonra defaults) >
+// built-in varsayılan.
+func (rs *runSettings) resolve(rawURL string) siteOptions {
+	opts := siteOptions{
+		Timeout:       25 * time.Second,
+		UserAgent:     "webscraper-go/1.0 (+https://example.com)",
+		Screenshot:    true,
+		WaitSelector:  "body",
+		ExtractLinks:  true,
+		Archive:       rs.flagArchive,
+		ArchiveFormat: rs.flagArchiveFormat,
+	}
+
+	var site *SiteConfig
+	if rs.cfg != nil {
+		if rs.cfg.Defaults.TimeoutSec > 0 {
+			opts.Timeout = time.Duration(rs.cfg.Defaults.TimeoutSec) * time.Second
+		}
+		if rs.cfg.Defaults.UserAgent != "" {
+			opts.UserAgent = rs.cfg.Defaults.UserAgent
+		}
+		if rs.cfg.Defaults.Screenshot != nil {
+			opts.Screenshot = *rs.cfg.Defaults.Screenshot
+		}
+		if d, err := time.ParseDuration(rs.cfg.Defaults.RateLimitDelay); err == nil {
+			opts.RateLimitDelay = d
+		}
+		site = rs.cfg.siteFor(rawURL)
+	}
+
+	if site != nil {
+		if len(site.Headers) > 0 {
+			opts.Headers = site.Headers
+		}
+		if len(site.Cookies) > 0 {
+			opts.Cookies = site.Cookies
+		}
+		if site.WaitSelector != "" {
+			opts.WaitSelector = site.WaitSelector
+		}
+		if site.ExtractLinks != nil {
+			opts.ExtractLinks = *site.ExtractLinks
+		}
+		if site.PostFetchJS != "" {
+			opts.PostFetchJS = site.PostFetchJS
+		}
+		if d, err := time.ParseDuration(site.RateLimitDelay); err == nil {
+			opts.RateLimitDelay = d
+		}
+	}
+
+	// Ortam değişkenleri, config dosyasından daha öncelikli.
+	if v := os.Getenv("SCRAPER_TIMEOUT_SECONDS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			opts.Timeout = time.Duration(n) * time.Second
+		}
+	}
+	if v := os.Getenv("SCRAPER_USER_AGENT"); v != "" {
+		opts.UserAgent = v
+	}
+	if v := os.Getenv("SCRAPER_NO_SCREENSHOT"); v != "" {
+		opts.Screenshot = v == "0" || strings.EqualFold(v, "false")
+	}
+
+	// Komut satırı bayrakları, açıkça verildiyse her şeyden önceliklidir.
+	if rs.explicitFlags["timeout"] {
+		opts.Timeout = time.Duration(rs.flagTimeoutSec) * time.Second
+	}
+	if rs.explicitFlags["no-screenshot"] {
+		opts.Screenshot = !rs.flagNoShot
+	}
+
+	return opts
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// -crawl modu: scrapeOne'i tekrar tekrar çağırıp extractLinks'in bulduğu
+// linkleri takip eden basit bir breadth-first crawler.
+//
+// Frontier (gezilecek URL kuyruğu) bilerek bellekte tutulmuyor: büyük
+// crawl'larda RAM patlamasın diye <out>/_crawl/.queue/ altında disk
+// üzerinde bir segment dosyasına append ediliyor, okuma ofseti de ayrı
+// bir dosyada tutuluyor. Daha önce kuyruğa eklenmiş URL'lerin SHA1'leri
+// seen.db'ye yazılıyor ki aynı link tekrar tekrar kuyruğa girmesin.
+
+type crawlRecord struct {
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// frontierQueue, tek bir segment dosyasına append-only kayıt yazan ve
+// ayrı bir offset dosyasından okuma pozisyonunu takip eden basit bir
+// disk kuyruğudur. Process yeniden başlasa da kaldığı yerden devam eder.
+type frontierQueue struct {
+	mu         sync.Mutex
+	dir        string
+	segPath    string
+	offsetPath string
+	segFile    *os.File
+	reader     *bufio.Reader
+	readFile   *os.File
+	offset     int64
+	pending    int // henüz okunmamış/işlenmemiş kayıt sayısının kaba tahmini
+}
+
+func newFrontierQueue(outDir string) (*frontierQueue, error) {
+	dir := filepath.Join(outDir, ".queue")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("kuyruk klasörü oluşturulamadı: %v", err)
+	}
+
+	q := &frontierQueue{
+		dir:        dir,
+		segPath:    filepath.Join(dir, "frontier.seg"),
+		offsetPath: filepath.Join(dir, "offset.txt"),
+	}
+
+	segFile, err := os.OpenFile(q.segPath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("kuyruk segment dosyası açılamadı: %v", err)
+	}
+	q.segFile = segFile
+
+	if b, err := os.ReadFile(q.offsetPath); err == nil {
+		fmt.Sscanf(strings.TrimSpace(string(b)), "%d", &q.offset)
+	}
+
+	readFile, err := os.Open(q.segPath)
+	if err != nil {
+		return nil, fmt.Errorf("kuyruk okunamadı: %v", err)
+	}
+	if _, err := readFile.Seek(q.offset, 0); err != nil {
+		readFile.Close()
+		return nil, err
+	}
+	q.readFile = readFile
+	q.reader = bufio.NewReader(readFile)
+
+	return q, nil
+}
+
+// Push, yeni bir URL/derinlik kaydını kuyruğun sonuna ekler.
+func (q *frontierQueue) Push(rec crawlRecord) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := q.segFile.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	q.pending++
+	return nil
+}
+
+// Pop, kuyruktan bir sonraki kaydı çeker. Kuyruk boşsa ok=false döner.
+func (q *frontierQueue) Pop() (rec crawlRecord, ok bool, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	line, rerr := q.reader.ReadString('\n')
+	if len(line) == 0 && rerr != nil {
+		return crawlRecord{}, false, nil
+	}
+
+	if jerr := json.Unmarshal([]byte(strings.TrimSpace(line)), &rec); jerr != nil {
+		// Bozuk satır: atla ama offset'i yine de ilerlet.
+		q.offset += int64(len(line))
+		q.saveOffsetLocked()
+		return crawlRecord{}, false, nil
+	}
+
+	q.offset += int64(len(line))
+	if q.pending > 0 {
+		q.pending--
+	}
+	q.saveOffsetLocked()
+	return rec, true, nil
+}
+
+func (q *frontierQueue) saveOffsetLocked() {
+	_ = os.WriteFile(q.offsetPath, []byte(fmt.Sprintf("%d\n", q.offset)), 0o644)
+}
+
+// Len, kuyrukta henüz işlenmemiş kayıtların kaba bir tahminini döner.
+// Dashboard'daki queue_length alanı için kullanılır; segment dosyasını
+// tekrar okumadığı için ucuzdur ama Pop sırasında bozuk satır atlanırsa
+// hafifçe iyimser kalabilir.
+func (q *frontierQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending
+}
+
+func (q *frontierQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_ = q.readFile.Close()
+	return q.segFile.Close()
+}
+
+// seenSet, daha önce kuyruğa konmuş URL'lerin SHA1 hash'lerini
+// <out>/_crawl/.queue/seen.db dosyasında tutan düz bir dosya.
+// Küçük/orta ölçekli crawl'lar için pratik bir "görüldü mü" filtresi;
+// hızlı erişim için satırlar açılışta belleğe okunur, her yeni hash
+// hem haritaya hem de dosyaya append edilir.
+type seenSet struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	set  map[string]struct{}
+}
+
+func newSeenSet(outDir string) (*seenSet, error) {
+	path := filepath.Join(outDir, ".queue", "seen.db")
+	s := &seenSet{path: path, set: make(map[string]struct{})}
+
+	if b, err := os.ReadFile(path); err == nil {
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				s.set[line] = struct{}{}
+			}
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("seen.db açılamadı: %v", err)
+	}
+	s.f = f
+	return s, nil
+}
+
+func hashURL(rawURL string) string {
+	h := sha1.Sum([]byte(rawURL))
+	return hex.EncodeToString(h[:])
+}
+
+// MarkIfNew, URL daha önce görülmediyse true döner ve kaydeder.
+func (s *seenSet) MarkIfNew(rawURL string) bool {
+	h := hashURL(rawURL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.set[h]; ok {
+		return false
+	}
+	s.set[h] = struct{}{}
+	_, _ = s.f.WriteString(h + "\n")
+	return true
+}
+
+func (s *seenSet) Close() error {
+	return s.f.Close()
+}
+
+// runCrawlMode, -crawl bayrağı verildiğinde main()'den çağrılan giriş
+// noktasıdır: main()'in çözdüğü tohum listesini (seeds boşsa defaultTargets)
+// kullanır, -cancel-after/-stop-after için context kurar ve runCrawl'ı
+// çalıştırıp özetini ekrana basar.
+func runCrawlMode(outRoot string, seeds []string, maxDepth, workers int, sameHostOnly bool, cancelAfter, stopAfter time.Duration, rs *runSettings, dash *dashboard) {
+	if len(seeds) == 0 {
+		seeds = append([]string{}, defaultTargets...)
+	}
+
+	if err := os.MkdirAll(outRoot, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "[-] output klasörü oluşturulamadı: %v\n", err)
+		os.Exit(2)
+	}
+
+	// cancelCtx: -cancel-after dolunca iptal olur ve devam eden fetch'ler de
+	// (scrapeOneWithOptions'a parent context olarak geçildiği için) bununla
+	// birlikte zorla kesilir.
+	cancelCtx := context.Background()
+	var hardCancel context.CancelFunc
+	if cancelAfter > 0 {
+		cancelCtx, hardCancel = context.WithTimeout(cancelCtx, cancelAfter)
+	}
+	if hardCancel != nil {
+		defer hardCancel()
+	}
+
+	// stopCtx: -stop-after dolunca (ya da cancelCtx iptal olunca) iptal olur,
+	// ama sadece worker döngüsünün yeni iş almasını durdurur; zaten devam
+	// eden bir fetch, kendi per-site timeout'una göre düzgünce biter.
+	stopCtx, stopCancel := context.WithCancel(cancelCtx)
+	defer stopCancel()
+	if stopAfter > 0 {
+		go func() {
+			select {
+			case <-time.After(stopAfter):
+				stopCancel()
+			case <-cancelCtx.Done():
+			}
+		}()
+	}
+
+	fmt.Printf("[*] Crawl başlıyor: %d tohum, derinlik<=%d, %d worker\n", len(seeds), maxDepth, workers)
+
+	opts := crawlOptions{
+		maxDepth:     maxDepth,
+		workers:      workers,
+		sameHostOnly: sameHostOnly,
+		outRoot:      outRoot,
+	}
+
+	results, err := runCrawl(stopCtx, cancelCtx, seeds, opts, rs, dash)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[-] crawl hatası: %v\n", err)
+		os.Exit(2)
+	}
+
+	total := 0
+	for _, pageResults := range results {
+		total += len(pageResults)
+	}
+	fmt.Printf("[+] Crawl bitti. Toplam %d sayfa gezildi. Özet: %s\n",
+		total, filepath.Join(outRoot, "_crawl", "crawl_summary.json"))
+}
+
+type crawlOptions struct {
+	maxDepth     int
+	workers      int
+	sameHostOnly bool
+	outRoot      string
+}
+
+// runCrawl, tohum URL'lerden başlayarak disk tabanlı frontier üzerinden
+// breadth-first bir crawl yürütür. Her sayfa, rs.resolve(url)'den gelen
+// siteOptions ile scrapeOneWithOptions üzerinden çekilir, böylece -config
+// (header/cookie/wait-selector/post-fetch-js/rate-limit/screenshot/archive)
+// batch moddaki gibi crawl'da da etkili olur. extractLinks'in bulduğu
+// linkler (host/derinlik filtrelerinden geçenler) kuyruğa eklenir.
+//
+// stopCtx iptal olunca worker'lar yeni iş almayı bırakır; cancelCtx iptal
+// olunca (stopCtx de zaten onun türevi olduğu için) ayrıca o an devam eden
+// sayfanın fetch/screenshot'ı da zorla kesilir.
+// Döner: derinliğe göre gruplanmış sonuçlar.
+func runCrawl(stopCtx, cancelCtx context.Context, seeds []string, opts crawlOptions, rs *runSettings, dash *dashboard) (map[int][]Result, error) {
+	crawlOutDir := filepath.Join(opts.outRoot, "_crawl")
+	if err := os.MkdirAll(crawlOutDir, 0o755); err != nil {
+		return nil, fmt.Errorf("crawl klasörü oluşturulamadı: %v", err)
+	}
+
+	frontier, err := newFrontierQueue(crawlOutDir)
+	if err != nil {
+		return nil, err
+	}
+	defer frontier.Close()
+
+	seen, err := newSeenSet(crawlOutDir)
+	if err != nil {
+		return nil, err
+	}
+	defer seen.Close()
+
+	seedHosts := make(map[string]struct{})
+	for _, s := range seeds {
+		if pu, err := url.Parse(s); err == nil {
+			seedHosts[pu.Host] = struct{}{}
+		}
+		if seen.MarkIfNew(s) {
+			_ = frontier.Push(crawlRecord{URL: s, Depth: 0})
+		}
+	}
+
+	if dash != nil {
+		dash.queueLenFn = func() int { return frontier.Len() }
+		dash.seedFn = func(rawURL string) error {
+			if seen.MarkIfNew(rawURL) {
+				return frontier.Push(crawlRecord{URL: rawURL, Depth: 0})
+			}
+			return nil
+		}
+	}
+
+	var (
+		resMu   sync.Mutex
+		results = make(map[int][]Result)
+	)
+
+	// busyWorkers, o an fetch/screenshot/link-çıkarma işiyle meşgul olan
+	// worker sayısını tutar. Frontier'i boş bulan bir worker, diğerlerinden
+	// en az biri meşgulken kesin çıkmamalı: o worker az sonra yeni linkler
+	// kuyruğa ekleyebilir. idleRound sayacı bu yüzden sadece "kimse meşgul
+	// değilken" ilerler; aksi halde worker havuzu derinlik arttıkça tek
+	// worker'a düşüp "pool" olmaktan çıkardı.
+	var busyWorkers int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			idleRound := 0
+			for {
+				select {
+				case <-stopCtx.Done():
+					return
+				default:
+				}
+
+				dash.WaitIfPaused(stopCtx)
+
+				rec, ok, perr := frontier.Pop()
+				if perr != nil {
+					return
+				}
+				if !ok {
+					dash.SetWorkerState(workerID, "", "idle")
+					if atomic.LoadInt32(&busyWorkers) > 0 {
+						idleRound = 0
+					} else {
+						idleRound++
+						if idleRound > 20 {
+							return
+						}
+					}
+					time.Sleep(50 * time.Millisecond)
+					continue
+				}
+				idleRound = 0
+
+				func() {
+					atomic.AddInt32(&busyWorkers, 1)
+					defer atomic.AddInt32(&busyWorkers, -1)
+
+					dash.SetWorkerState(workerID, rec.URL, "fetching")
+
+					res := scrapeOneWithOptions(cancelCtx, rec.URL, opts.outRoot, rs.resolve(rec.URL))
+					res = dash.RecordResult(res)
+					dash.SetWorkerState(workerID, rec.URL, "done")
+
+					resMu.Lock()
+					results[rec.Depth] = append(results[rec.Depth], res)
+					resMu.Unlock()
+
+					if rec.Depth >= opts.maxDepth {
+						return
+					}
+
+					htmlPath := filepath.Join(res.OutDir, "site_data.html")
+					body, rerr := os.ReadFile(htmlPath)
+					if rerr != nil {
+						return
+					}
+					parsed, perr2 := url.Parse(rec.URL)
+					if perr2 != nil {
+						return
+					}
+
+					for _, link := range extractLinks(parsed, body) {
+						if opts.sameHostOnly {
+							lu, lerr := url.Parse(link)
+							if lerr != nil {
+								continue
+							}
+							if _, ok := seedHosts[lu.Host]; !ok {
+								continue
+							}
+						}
+						if seen.MarkIfNew(link) {
+							_ = frontier.Push(crawlRecord{URL: link, Depth: rec.Depth + 1})
+						}
+					}
+				}()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	summaryPath := filepath.Join(crawlOutDir, "crawl_summary.json")
+	byDepth := make(map[string][]Result, len(results))
+	for d, pageResults := range results {
+		byDepth[fmt.Sprintf("%d", d)] = pageResults
+	}
+	_ = writeJSON(summaryPath, byDepth)
+
+	return results, nil
+}
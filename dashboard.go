@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// -dashboard :8080 verildiğinde, scrape/crawl işlemi devam ederken
+// durumu izlemek ve (biraz da) kontrol etmek için küçük bir HTTP
+// sunucusu açılır. Uzun toplu koşularda (15 hedef ya da bir crawl)
+// süreci öldürmeden ilerlemeyi görmek için kullanışlı.
+
+// workerStatus, tek bir worker'ın o anki durumunu tutar.
+type workerStatus struct {
+	ID          int       `json:"id"`
+	CurrentURL  string    `json:"current_url,omitempty"`
+	State       string    `json:"state"` // idle | fetching | done
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+type statusPayload struct {
+	StartedAt      time.Time            `json:"started_at"`
+	ElapsedSeconds float64              `json:"elapsed_seconds"`
+	Paused         bool                 `json:"paused"`
+	QueueLength    int                  `json:"queue_length"`
+	PagesDone      int64                `json:"pages_done"`
+	BytesDown      int64                `json:"bytes_downloaded"`
+	ErrorCount     int64                `json:"error_count"`
+	PagesPerSec    float64              `json:"pages_per_sec"`
+	Workers        map[int]workerStatus `json:"workers"`
+}
+
+// dashboard, bir scrape/crawl koşusunun paylaşılan durumunu tutar: worker
+// bazlı ilerleme, toplam sayaçlar, pause/resume bayrağı ve SSE ile
+// yayınlanan sonuç akışı.
+type dashboard struct {
+	startedAt time.Time
+
+	mu      sync.Mutex
+	workers map[int]workerStatus
+
+	paused int32 // atomic: 0 = çalışıyor, 1 = duraklatıldı
+	cond   *sync.Cond
+	condMu sync.Mutex
+
+	pagesDone int64 // atomic
+	bytesDown int64 // atomic
+	errCount  int64 // atomic
+	nextID    int64 // atomic
+
+	queueLenFn func() int
+	seedFn     func(url string) error
+
+	subMu sync.Mutex
+	subs  map[chan Result]struct{}
+
+	resMu   sync.Mutex
+	results []Result // son 500 sonuç; /api/status bunları döndürmez
+}
+
+func newDashboard() *dashboard {
+	d := &dashboard{
+		startedAt: time.Now(),
+		workers:   make(map[int]workerStatus),
+		subs:      make(map[chan Result]struct{}),
+	}
+	d.cond = sync.NewCond(&d.condMu)
+	return d
+}
+
+// SetWorkerState, bir worker'ın şu an hangi URL üzerinde ne yaptığını günceller.
+func (d *dashboard) SetWorkerState(id int, url, state string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	d.workers[id] = workerStatus{ID: id, CurrentURL: url, State: state, LastUpdated: time.Now()}
+	d.mu.Unlock()
+}
+
+// WaitIfPaused, /api/pause çağrılmışsa /api/resume gelene veya ctx iptal
+// olana kadar bloklar. Worker'lar bir sonraki URL'i işlemeden önce çağırır.
+func (d *dashboard) WaitIfPaused(ctx context.Context) {
+	if d == nil {
+		return
+	}
+	if atomic.LoadInt32(&d.paused) == 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.condMu.Lock()
+		for atomic.LoadInt32(&d.paused) == 1 {
+			d.cond.Wait()
+		}
+		d.condMu.Unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// RecordResult, bitmiş bir Result'a artan bir ID atar, sayaçları günceller
+// ve SSE abonelerine yayınlar. ID yalnızca dönen Result'ta (ve onu toplayan
+// summary.json / crawl_summary.json'da) bulunur; scrapeOneWithOptions'ın
+// yazdığı meta.json bu fonksiyondan önce yazıldığı için ID taşımaz.
+func (d *dashboard) RecordResult(res Result) Result {
+	if d == nil {
+		return res
+	}
+	res.ID = atomic.AddInt64(&d.nextID, 1)
+
+	atomic.AddInt64(&d.pagesDone, 1)
+	atomic.AddInt64(&d.bytesDown, res.BytesDownloaded)
+	if res.Error != "" {
+		atomic.AddInt64(&d.errCount, 1)
+	}
+
+	d.resMu.Lock()
+	d.results = append(d.results, res)
+	if len(d.results) > 500 {
+		d.results = d.results[len(d.results)-500:]
+	}
+	d.resMu.Unlock()
+
+	d.broadcast(res)
+	return res
+}
+
+func (d *dashboard) broadcast(res Result) {
+	d.subMu.Lock()
+	defer d.subMu.Unlock()
+	for ch := range d.subs {
+		select {
+		case ch <- res:
+		default:
+			// Yavaş abone: en yeni sonucu kaçırmak, tüm yayını kilitlemekten iyidir.
+		}
+	}
+}
+
+func (d *dashboard) subscribe() chan Result {
+	ch := make(chan Result, 32)
+	d.subMu.Lock()
+	d.subs[ch] = struct{}{}
+	d.subMu.Unlock()
+	return ch
+}
+
+func (d *dashboard) unsubscribe(ch chan Result) {
+	d.subMu.Lock()
+	delete(d.subs, ch)
+	d.subMu.Unlock()
+	close(ch)
+}
+
+func (d *dashboard) snapshot() statusPayload {
+	d.mu.Lock()
+	workersCopy := make(map[int]workerStatus, len(d.workers))
+	for k, v := range d.workers {
+		workersCopy[k] = v
+	}
+	d.mu.Unlock()
+
+	elapsed := time.Since(d.startedAt).Seconds()
+	done := atomic.LoadInt64(&d.pagesDone)
+	pps := 0.0
+	if elapsed > 0 {
+		pps = float64(done) / elapsed
+	}
+
+	queueLen := 0
+	if d.queueLenFn != nil {
+		queueLen = d.queueLenFn()
+	}
+
+	return statusPayload{
+		StartedAt:      d.startedAt,
+		ElapsedSeconds: elapsed,
+		Paused:         atomic.LoadInt32(&d.paused) == 1,
+		QueueLength:    queueLen,
+		PagesDone:      done,
+		BytesDown:      atomic.LoadInt64(&d.bytesDown),
+		ErrorCount:     atomic.LoadInt64(&d.errCount),
+		PagesPerSec:    pps,
+		Workers:        workersCopy,
+	}
+}
+
+// Start, dashboard HTTP sunucusunu arka planda ayağa kaldırır. addr boşsa
+// hiçbir şey yapmaz (örn: ":8080").
+func (d *dashboard) Start(addr string) {
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(dashboardHTML))
+	})
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(d.snapshot())
+	})
+
+	mux.HandleFunc("/api/pause", func(w http.ResponseWriter, r *http.Request) {
+		atomic.StoreInt32(&d.paused, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/resume", func(w http.ResponseWriter, r *http.Request) {
+		atomic.StoreInt32(&d.paused, 0)
+		d.condMu.Lock()
+		d.cond.Broadcast()
+		d.condMu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/api/seed", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "sadece POST", http.StatusMethodNotAllowed)
+			return
+		}
+		var body struct {
+			URL string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.URL == "" {
+			http.Error(w, "geçersiz istek gövdesi, {\"url\":\"...\"} bekleniyor", http.StatusBadRequest)
+			return
+		}
+		if d.seedFn == nil {
+			http.Error(w, "bu modda runtime seed desteklenmiyor", http.StatusNotImplemented)
+			return
+		}
+		if err := d.seedFn(body.URL); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/api/results/stream", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming desteklenmiyor", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		ch := d.subscribe()
+		defer d.unsubscribe(ch)
+
+		for {
+			select {
+			case res, ok := <-ch:
+				if !ok {
+					return
+				}
+				b, _ := json.Marshal(res)
+				fmt.Fprintf(w, "data: %s\n\n", b)
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("[-] dashboard sunucusu kapandı: %v", err)
+		}
+	}()
+	fmt.Printf("[*] Dashboard açık: http://localhost%s/\n", addr)
+}
+
+// extraSeedQueue, toplu (-all) modda dashboard'un /api/seed endpoint'i
+// üzerinden eklenen URL'leri statik hedef listesi bitene kadar biriktiren
+// basit, kilitli bir kuyruktur. Crawl modunun disk tabanlı frontier'ının
+// aksine burada bellekte tutulması yeterli: toplu mod zaten sınırlı sayıda
+// hedefle sınırlı bir komut satırı aracı.
+type extraSeedQueue struct {
+	mu    sync.Mutex
+	items []string
+}
+
+func (q *extraSeedQueue) Push(url string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = append(q.items, url)
+	return nil
+}
+
+func (q *extraSeedQueue) Pop() (string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return "", false
+	}
+	u := q.items[0]
+	q.items = q.items[1:]
+	return u, true
+}
+
+func (q *extraSeedQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+const dashboardHTML = `<!DOCTYPE html>
+<html lang="tr">
+<head>
+<meta charset="utf-8">
+<title>web-scraper-go dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; background: #111; color: #eee; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border: 1px solid #444; padding: 4px 8px; text-align: left; }
+button { margin-right: 8px; }
+</style>
+</head>
+<body>
+<h1>web-scraper-go</h1>
+<div>
+  <button onclick="fetch('/api/pause',{method:'POST'})">Duraklat</button>
+  <button onclick="fetch('/api/resume',{method:'POST'})">Devam et</button>
+</div>
+<pre id="status">yükleniyor...</pre>
+<table id="workers"><thead><tr><th>worker</th><th>durum</th><th>url</th></tr></thead><tbody></tbody></table>
+<script>
+async function tick() {
+  const r = await fetch('/api/status');
+  const s = await r.json();
+  document.getElementById('status').textContent =
+    'pages=' + s.pages_done + ' errors=' + s.error_count +
+    ' bytes=' + s.bytes_downloaded + ' pps=' + s.pages_per_sec.toFixed(2) +
+    ' queue=' + s.queue_length + ' paused=' + s.paused;
+  const tbody = document.querySelector('#workers tbody');
+  tbody.innerHTML = '';
+  for (const id in s.workers) {
+    const w = s.workers[id];
+    const tr = document.createElement('tr');
+    tr.innerHTML = '<td>' + w.id + '</td><td>' + w.state + '</td><td>' + (w.current_url || '') + '</td>';
+    tbody.appendChild(tr);
+  }
+}
+setInterval(tick, 1000);
+tick();
+</script>
+</body>
+</html>`
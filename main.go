@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha1"
 	"encoding/hex"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+	"golang.org/x/net/html"
 )
 
 // 15 adet örnek URL (ödevdeki "15 farklı site üstünde dene" kısmı için)
@@ -40,15 +42,17 @@ var defaultTargets = []string{
 }
 
 type Result struct {
-	URL            string `json:"url"`
-	OutDir         string `json:"out_dir"`
-	HTTPStatus     int    `json:"http_status"`
-	HTTPStatusText string `json:"http_status_text"`
-	FetchElapsedMS int64  `json:"fetch_elapsed_ms"`
-	ScreenshotOK   bool   `json:"screenshot_ok"`
-	LinksFound     int    `json:"links_found"`
-	Error          string `json:"error,omitempty"`
-	TimestampUTC   string `json:"timestamp_utc"`
+	ID              int64  `json:"id"`
+	URL             string `json:"url"`
+	OutDir          string `json:"out_dir"`
+	HTTPStatus      int    `json:"http_status"`
+	HTTPStatusText  string `json:"http_status_text"`
+	FetchElapsedMS  int64  `json:"fetch_elapsed_ms"`
+	BytesDownloaded int64  `json:"bytes_downloaded"`
+	ScreenshotOK    bool   `json:"screenshot_ok"`
+	LinksFound      int    `json:"links_found"`
+	Error           string `json:"error,omitempty"`
+	TimestampUTC    string `json:"timestamp_utc"`
 }
 
 func main() {
@@ -58,22 +62,113 @@ func main() {
 		outRoot    = flag.String("out", "output", "Çıktı klasörü")
 		timeoutSec = flag.Int("timeout", 25, "Her site için timeout (saniye)")
 		noShot     = flag.Bool("no-screenshot", false, "Ekran görüntüsü alma (sadece HTML+link)")
+
+		crawl        = flag.Bool("crawl", false, "Tohum URL'lerden başlayıp linkleri takip eden crawl modu")
+		crawlDepth   = flag.Int("depth", 2, "Crawl modunda en fazla kaç seviye derine inilsin")
+		crawlWorkers = flag.Int("workers", 4, "Crawl modunda paralel worker sayısı")
+		sameHost     = flag.Bool("same-host", false, "Crawl sadece tohum URL'lerle aynı host'ta kalsın")
+		cancelAfter  = flag.Duration("cancel-after", 0, "Bu süre sonunda devam eden istekler iptal edilir (örn: 5m)")
+		stopAfter    = flag.Duration("stop-after", 0, "Bu süre sonunda yeni iş başlatılmaz, crawl düzgünce biter (örn: 4m)")
+
+		dashboardAddr = flag.String("dashboard", "", "Koşuyu izlemek/kontrol etmek için dashboard adresi (örn: :8080)")
+
+		serveAddr = flag.String("serve", "", "Render edilmiş sayfaları paletli GIF olarak sunan proxy adresi (örn: :8080)")
+
+		configPath = flag.String("config", "", "defaults + sites bloklarından oluşan YAML/JSON config dosyası")
+
+		seedsSrc = flag.String("seeds", "", "Düz metin/OPML/sitemap.xml'den hedef listesi oku (yerel yol ya da http(s) URL)")
+
+		archive       = flag.Bool("archive", false, "Her sayfa için assets'leri indirip offline açılabilir bir snapshot üret")
+		archiveFormat = flag.String("archive-format", "files", "archive snapshot biçimi: files (varsayılan) ya da warc")
 	)
 	flag.Parse()
 
+	explicitFlags := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+
+	var cfg *Config
+	if *configPath != "" {
+		var err error
+		cfg, err = loadConfig(*configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[-] config yüklenemedi: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	rs := newRunSettings(cfg, explicitFlags, *timeoutSec, *noShot, *archive, *archiveFormat)
+
+	if *serveAddr != "" {
+		runServeMode(*serveAddr, time.Duration(*timeoutSec)*time.Second)
+		return
+	}
+
+	var dash *dashboard
+	if *dashboardAddr != "" {
+		dash = newDashboard()
+		dash.Start(*dashboardAddr)
+	}
+
+	if *crawl {
+		workers := *crawlWorkers
+		if !explicitFlags["workers"] && cfg != nil && cfg.Defaults.Concurrency > 0 {
+			// config'in "concurrency" alanı, -workers açıkça verilmediğinde
+			// crawl'ın paralellik derecesini belirler.
+			workers = cfg.Defaults.Concurrency
+		}
+
+		// -seeds, toplu moddaki gibi crawl'ın da tohum listesini belirleyebilmeli;
+		// verilmediyse sırasıyla komut satırı argümanlarına, config'teki sites
+		// listesine, o da yoksa runCrawlMode'un kendi defaultTargets'ına düşer.
+		var crawlSeeds []string
+		if *seedsSrc != "" {
+			seeded, err := resolveSeeds(*seedsSrc)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[-] seeds okunamadı: %v\n", err)
+				os.Exit(2)
+			}
+			crawlSeeds = seeded
+		} else if len(flag.Args()) > 0 {
+			crawlSeeds = append([]string{}, flag.Args()...)
+		} else if cfg != nil && len(cfg.Sites) > 0 {
+			for _, s := range cfg.Sites {
+				crawlSeeds = append(crawlSeeds, s.URL)
+			}
+		}
+
+		runCrawlMode(*outRoot, crawlSeeds, *crawlDepth, workers, *sameHost, *cancelAfter, *stopAfter, rs, dash)
+		return
+	}
+
 	// Ödev: URL komut satırı argümanı ile alınabilmeli.
 	// -> -url verildiğinde tek hedef çalışır.
 	// Kullanıcı "tek tek uğraşmak istemiyorum" dediği için,
 	// -> -all ile kod içindeki 15 hedefi otomatik geziyoruz.
 	var targets []string
-	if *urlArg != "" {
+	if *seedsSrc != "" {
+		seeded, err := resolveSeeds(*seedsSrc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[-] seeds okunamadı: %v\n", err)
+			os.Exit(2)
+		}
+		targets = seeded
+	} else if *urlArg != "" {
 		targets = []string{*urlArg}
-	} else if *all || len(flag.Args()) == 0 {
-		// default: -url verilmediyse ve başka arg da yoksa toplu çalıştır
-		targets = append([]string{}, defaultTargets...)
-	} else {
+	} else if !*all && len(flag.Args()) > 0 {
 		// İstersen: go run . https://site.com gibi argüman da destekleyelim
 		targets = append([]string{}, flag.Args()...)
+	} else if cfg != nil && len(cfg.Sites) > 0 {
+		// config dosyasındaki sites listesi, kod içindeki defaultTargets'ın
+		// yerini alır: kullanıcı listeyi main.go'ya dokunmadan büyütebilsin.
+		for _, s := range cfg.Sites {
+			targets = append(targets, s.URL)
+		}
+	} else {
+		// default: -url verilmediyse ve başka arg da yoksa toplu çalıştır
+		targets = append([]string{}, defaultTargets...)
+	}
+
+	if cfg != nil && cfg.Defaults.OutputRoot != "" && !explicitFlags["out"] {
+		*outRoot = cfg.Defaults.OutputRoot
 	}
 
 	if err := os.MkdirAll(*outRoot, 0o755); err != nil {
@@ -81,11 +176,42 @@ func main() {
 		os.Exit(2)
 	}
 
+	// Dashboard açıksa, /api/seed ile runtime'da eklenen URL'ler bu kuyruğa düşer
+	// ve statik hedef listesi bitince sırayla işlenir.
+	var extraSeeds extraSeedQueue
+	var i int
+	if dash != nil {
+		dash.seedFn = extraSeeds.Push
+		// queueLenFn, i'yi (döngünün o anki ilerleme indeksini) kapatarak
+		// kalan iş sayısını canlı döner; crawl modundaki frontier.Len()'in
+		// yaptığının toplu moddaki karşılığı.
+		dash.queueLenFn = func() int {
+			remaining := len(targets) - i
+			if remaining < 0 {
+				remaining = 0
+			}
+			return remaining + extraSeeds.Len()
+		}
+	}
+
 	results := make([]Result, 0, len(targets))
-	for i, t := range targets {
-		fmt.Printf("\n[%d/%d] %s\n", i+1, len(targets), t)
+	for ; ; i++ {
+		var t string
+		if i < len(targets) {
+			t = targets[i]
+		} else if next, ok := extraSeeds.Pop(); ok {
+			t = next
+		} else {
+			break
+		}
+
+		dash.WaitIfPaused(context.Background())
+		dash.SetWorkerState(0, t, "fetching")
+		fmt.Printf("\n[%d] %s\n", i+1, t)
 
-		res := scrapeOne(t, *outRoot, time.Duration(*timeoutSec)*time.Second, !*noShot)
+		res := scrapeOneWithOptions(context.Background(), t, *outRoot, rs.resolve(t))
+		res = dash.RecordResult(res)
+		dash.SetWorkerState(0, t, "done")
 		results = append(results, res)
 
 		if res.Error != "" {
@@ -103,7 +229,16 @@ func main() {
 	fmt.Printf("\n[+] Bitti. Özet: %s\n", summaryPath)
 }
 
-func scrapeOne(rawURL, outRoot string, perSiteTimeout time.Duration, doScreenshot bool) Result {
+// scrapeOneWithOptions, tek bir URL'i rs.resolve(url)'den gelen (flag/env/
+// config'ten çözülmüş) siteOptions ile çeker; batch mod ve -crawl ortak
+// olarak bunu kullanır, böylece -config her iki modda da aynı şekilde
+// etkili olur.
+//
+// parentCtx, opts.Timeout'un türetileceği üst context'tir: -crawl modunda bu,
+// -cancel-after ile kurulan context'tir, böylece o süre dolduğunda devam
+// eden fetch/screenshot de iptal olur (yoksa sadece kendi per-site
+// timeout'u dolana kadar çalışmaya devam ederdi).
+func scrapeOneWithOptions(parentCtx context.Context, rawURL, outRoot string, opts siteOptions) Result {
 	start := time.Now().UTC()
 	res := Result{
 		URL:          rawURL,
@@ -124,15 +259,20 @@ func scrapeOne(rawURL, outRoot string, perSiteTimeout time.Duration, doScreensho
 		return res
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), perSiteTimeout)
+	if opts.RateLimitDelay > 0 {
+		time.Sleep(opts.RateLimitDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, opts.Timeout)
 	defer cancel()
 
 	// 1) HTML çek
 	fetchStart := time.Now()
-	statusCode, statusText, body, err := fetchHTML(ctx, parsed.String())
+	statusCode, statusText, body, err := fetchHTML(ctx, parsed.String(), opts)
 	res.FetchElapsedMS = time.Since(fetchStart).Milliseconds()
 	res.HTTPStatus = statusCode
 	res.HTTPStatusText = statusText
+	res.BytesDownloaded = int64(len(body))
 
 	if err != nil {
 		res.Error = err.Error()
@@ -147,16 +287,25 @@ func scrapeOne(rawURL, outRoot string, perSiteTimeout time.Duration, doScreensho
 		return res
 	}
 
-	// 2) Linkleri çıkar (ek puan)
-	links := extractLinks(parsed, body)
-	res.LinksFound = len(links)
-	linksPath := filepath.Join(outDir, "links.txt")
-	_ = os.WriteFile(linksPath, []byte(strings.Join(links, "\n")+"\n"), 0o644)
+	// 2) Linkleri çıkar (ek puan) - config'te extract_links: false verilmişse atla
+	if opts.ExtractLinks {
+		links := extractLinks(parsed, body)
+		res.LinksFound = len(links)
+		linksPath := filepath.Join(outDir, "links.txt")
+		_ = os.WriteFile(linksPath, []byte(strings.Join(links, "\n")+"\n"), 0o644)
+	}
+
+	// 2.5) -archive: offline görüntüleme için kendi kendine yeten bir snapshot
+	if opts.Archive {
+		if err := archivePage(parsed, body, outDir, opts); err != nil {
+			fmt.Printf("   [-] archive hatası: %v\n", err)
+		}
+	}
 
 	// 3) Screenshot (chromedp)
-	if doScreenshot {
+	if opts.Screenshot {
 		ssPath := filepath.Join(outDir, "screenshot.png")
-		if err := takeScreenshot(ctx, parsed.String(), ssPath); err != nil {
+		if err := takeScreenshot(ctx, parsed.String(), ssPath, opts); err != nil {
 			res.ScreenshotOK = false
 			// Screenshot hata olsa bile HTML+link zaten kaydedildi; hata mesajını meta'ya yazalım
 			res.Error = "screenshot alınamadı: " + err.Error()
@@ -169,17 +318,26 @@ func scrapeOne(rawURL, outRoot string, perSiteTimeout time.Duration, doScreensho
 	return res
 }
 
-func fetchHTML(ctx context.Context, target string) (int, string, []byte, error) {
+func fetchHTML(ctx context.Context, target string, opts siteOptions) (int, string, []byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
 	if err != nil {
 		return 0, "", nil, fmt.Errorf("istek oluşturulamadı: %v", err)
 	}
 
 	// Basit User-Agent (bazı siteler boş UA sevmez)
-	req.Header.Set("User-Agent", "webscraper-go/1.0 (+https://example.com)")
+	req.Header.Set("User-Agent", opts.UserAgent)
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+	for _, c := range opts.Cookies {
+		req.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
+	}
 
+	// İstek süresi burada değil, çağıranın verdiği ctx üzerinden sınırlanıyor
+	// (opts.Timeout'tan türetilmiş); http.Client'a ayrıca sabit bir Timeout
+	// koymak -config'teki timeout_seconds'ı görmezden gelip her isteği 20sn'de
+	// keserdi.
 	client := &http.Client{
-		Timeout: 20 * time.Second,
 		// Redirect default olarak takip edilir; istersen sınır koyabilirsin.
 	}
 
@@ -202,30 +360,41 @@ func fetchHTML(ctx context.Context, target string) (int, string, []byte, error)
 	return resp.StatusCode, resp.Status, b, nil
 }
 
-func takeScreenshot(ctx context.Context, target, outPath string) error {
-	// chromedp kendi context'ini istiyor; dış timeout ctx'ini de kullanacağız
-	allocCtx, cancel := chromedp.NewExecAllocator(ctx,
-		append(chromedp.DefaultExecAllocatorOptions[:],
-			chromedp.Flag("headless", true),
-			chromedp.Flag("disable-gpu", true),
-			chromedp.Flag("no-sandbox", true),
-			chromedp.Flag("disable-dev-shm-usage", true),
-			// Stabilite için:
-			chromedp.WindowSize(1366, 768),
-		)...,
+func takeScreenshot(ctx context.Context, target, outPath string, opts siteOptions) error {
+	execOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+		chromedp.Flag("disable-dev-shm-usage", true),
+		// Stabilite için:
+		chromedp.WindowSize(1366, 768),
 	)
+	if opts.UserAgent != "" {
+		execOpts = append(execOpts, chromedp.UserAgent(opts.UserAgent))
+	}
+
+	// chromedp kendi context'ini istiyor; dış timeout ctx'ini de kullanacağız
+	allocCtx, cancel := chromedp.NewExecAllocator(ctx, execOpts...)
 	defer cancel()
 
 	bctx, cancel2 := chromedp.NewContext(allocCtx)
 	defer cancel2()
 
+	waitSelector := opts.WaitSelector
+	if waitSelector == "" {
+		waitSelector = "body"
+	}
+
 	var png []byte
 	tasks := chromedp.Tasks{
 		chromedp.Navigate(target),
-		chromedp.WaitReady("body", chromedp.ByQuery),
-		// FullScreenshot: sayfanın tamamını çeker
-		chromedp.FullScreenshot(&png, 90),
+		chromedp.WaitReady(waitSelector, chromedp.ByQuery),
 	}
+	if opts.PostFetchJS != "" {
+		tasks = append(tasks, chromedp.Evaluate(opts.PostFetchJS, nil))
+	}
+	// FullScreenshot: sayfanın tamamını çeker
+	tasks = append(tasks, chromedp.FullScreenshot(&png, 90))
 
 	if err := chromedp.Run(bctx, tasks); err != nil {
 		return err
@@ -233,50 +402,39 @@ func takeScreenshot(ctx context.Context, target, outPath string) error {
 	return os.WriteFile(outPath, png, 0o644)
 }
 
+// extractLinks, sayfadaki her <a href="..."> hedefini baseURL'e göre mutlak
+// bir URL'e çevirip (fragment atılmış, tekilleştirilmiş, sıralı) döner.
+// archive.go'daki collectAssetRefs ile aynı golang.org/x/net/html DOM
+// yürüyüşünü kullanır; eskiden burada elle "href=" taraması yapılıyordu.
 func extractLinks(baseURL *url.URL, htmlBytes []byte) []string {
-	// Çok basit bir "href" çekme: HTML parse etmiyoruz, ama pratikte iş görür.
-	// İstersen later: golang.org/x/net/html ile token token parse edebilirsin.
-	s := string(htmlBytes)
-	found := make(map[string]struct{})
+	doc, err := html.Parse(bytes.NewReader(htmlBytes))
+	if err != nil {
+		return nil
+	}
 
-	// aşırı basit tarama: href="..."
-	lower := strings.ToLower(s)
-	idx := 0
-	for {
-		p := strings.Index(lower[idx:], "href=")
-		if p < 0 {
-			break
-		}
-		p = p + idx
-		q := p + len("href=")
-		if q >= len(s) {
-			break
-		}
-		quote := s[q]
-		if quote != '"' && quote != '\'' {
-			idx = q
-			continue
-		}
-		q++ // open quote sonrası
-		end := strings.IndexByte(s[q:], quote)
-		if end < 0 {
-			break
-		}
-		rawHref := strings.TrimSpace(s[q : q+end])
-		idx = q + end + 1
+	found := make(map[string]struct{})
 
-		if rawHref == "" || strings.HasPrefix(rawHref, "#") || strings.HasPrefix(strings.ToLower(rawHref), "javascript:") || strings.HasPrefix(strings.ToLower(rawHref), "mailto:") {
-			continue
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			if idx := findAttr(n, "href"); idx >= 0 {
+				rawHref := strings.TrimSpace(n.Attr[idx].Val)
+				lower := strings.ToLower(rawHref)
+				if rawHref != "" && !strings.HasPrefix(rawHref, "#") &&
+					!strings.HasPrefix(lower, "javascript:") && !strings.HasPrefix(lower, "mailto:") {
+					if u, err := url.Parse(rawHref); err == nil {
+						abs := baseURL.ResolveReference(u)
+						abs.Fragment = "" // # kısmını at
+						found[abs.String()] = struct{}{}
+					}
+				}
+			}
 		}
-
-		u, err := url.Parse(rawHref)
-		if err != nil {
-			continue
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
 		}
-		abs := baseURL.ResolveReference(u)
-		abs.Fragment = "" // # kısmını at
-		found[abs.String()] = struct{}{}
 	}
+	visit(doc)
 
 	out := make([]string, 0, len(found))
 	for k := range found {
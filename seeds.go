@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kaorimatz/go-opml"
+)
+
+// -seeds path_or_url, hedef listesini elle defaultTargets'a eklemek yerine
+// üç formattan birinden okur: düz metin (satır başına bir URL), bir OPML
+// feed listesi (<outline xmlUrl="...">) ya da bir sitemap.xml / sitemap
+// index. Sonuç, main()'deki statik hedef listesinin yerini alır.
+
+const maxSitemapIndexDepth = 3
+
+// resolveSeeds, -seeds argümanını (yerel dosya yolu ya da http(s) URL'i)
+// okuyup biçimini otomatik algılayarak düz bir URL listesine çevirir.
+func resolveSeeds(pathOrURL string) ([]string, error) {
+	body, err := readSeedSource(pathOrURL)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	switch {
+	case strings.Contains(trimmed, "<opml"):
+		return parseOPMLSeeds(body)
+	case strings.Contains(trimmed, "<urlset") || strings.Contains(trimmed, "<sitemapindex"):
+		return parseSitemapSeeds(body, 0)
+	default:
+		return parsePlainTextSeeds(body), nil
+	}
+}
+
+// readSeedSource, -seeds hedefi bir http(s) URL'iyse fetchHTML'in HTTP
+// istemcisini tekrar kullanarak indirir (link çıkarma adımı burada devre
+// dışı, sadece ham içerik lazım); yerel bir yolsa doğrudan okur.
+func readSeedSource(pathOrURL string) ([]byte, error) {
+	if strings.HasPrefix(pathOrURL, "http://") || strings.HasPrefix(pathOrURL, "https://") {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+
+		opts := siteOptions{UserAgent: "webscraper-go/1.0 (+https://example.com)"}
+		_, _, body, err := fetchHTML(ctx, pathOrURL, opts)
+		if err != nil {
+			return nil, fmt.Errorf("seed kaynağı indirilemedi: %v", err)
+		}
+		return body, nil
+	}
+
+	b, err := os.ReadFile(pathOrURL)
+	if err != nil {
+		return nil, fmt.Errorf("seed dosyası okunamadı: %v", err)
+	}
+	return b, nil
+}
+
+func parsePlainTextSeeds(body []byte) []string {
+	var out []string
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// parseOPMLSeeds, OPML ağacındaki her <outline xmlUrl="..."> girdisini
+// (klasör gibi iç içe outline'lar dahil) topluyor.
+func parseOPMLSeeds(body []byte) ([]string, error) {
+	doc, err := opml.NewParser(strings.NewReader(string(body))).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("OPML parse edilemedi: %v", err)
+	}
+
+	var out []string
+	var walk func(outlines []*opml.Outline)
+	walk = func(outlines []*opml.Outline) {
+		for _, o := range outlines {
+			if o.XMLURL != nil {
+				out = append(out, o.XMLURL.String())
+			}
+			if len(o.Outlines) > 0 {
+				walk(o.Outlines)
+			}
+		}
+	}
+	walk(doc.Outlines)
+	return out, nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// parseSitemapSeeds, bir sitemap.xml (<urlset>) ya da sitemap index
+// (<sitemapindex>) gövdesini <loc> değerlerine çevirir. Index ise, her alt
+// sitemap http(s) ise indirilip özyinelemeli olarak genişletilir.
+func parseSitemapSeeds(body []byte, depth int) ([]string, error) {
+	var idx sitemapIndex
+	if err := xml.Unmarshal(body, &idx); err == nil && len(idx.Sitemaps) > 0 {
+		if depth >= maxSitemapIndexDepth {
+			return nil, fmt.Errorf("sitemap index çok derin iç içe (>%d), durduruldu", maxSitemapIndexDepth)
+		}
+		var out []string
+		for _, sm := range idx.Sitemaps {
+			if sm.Loc == "" {
+				continue
+			}
+			childBody, err := readSeedSource(sm.Loc)
+			if err != nil {
+				return nil, err
+			}
+			childURLs, err := parseSitemapSeeds(childBody, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, childURLs...)
+		}
+		return out, nil
+	}
+
+	var set sitemapURLSet
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("sitemap parse edilemedi: %v", err)
+	}
+	out := make([]string, 0, len(set.URLs))
+	for _, u := range set.URLs {
+		if u.Loc != "" {
+			out = append(out, u.Loc)
+		}
+	}
+	return out, nil
+}
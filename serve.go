@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/ericpauley/go-quantize/quantize"
+)
+
+// -serve :8080 modu: chromedp ile sayfayı gerçek bir tarayıcıda render edip
+// ekran görüntüsünü paletli bir GIF'e indirgeyerek, eski/basit tarayıcıların
+// bile modern sayfaları "görebilmesini" sağlayan bir proxy açar. Linkler,
+// DOM'dan toplanan <a> konumlarıyla bir image map olarak resme eklenir; bir
+// bağlantıya tıklamak proxy'yi o URL'e yönlendirir.
+
+// renderedAnchor, sayfa render edildiğinde DOM'dan toplanan tek bir <a>
+// elemanının viewport'a göre konumu ve hedef adresidir.
+type renderedAnchor struct {
+	X, Y, W, H int
+	Href       string
+}
+
+const collectAnchorsJS = `
+(function() {
+  var out = [];
+  var anchors = document.querySelectorAll('a[href]');
+  for (var i = 0; i < anchors.length; i++) {
+    var r = anchors[i].getBoundingClientRect();
+    if (r.width <= 0 || r.height <= 0) continue;
+    if (r.bottom < 0 || r.top > window.innerHeight) continue;
+    out.push({
+      x: Math.round(r.left),
+      y: Math.round(r.top),
+      w: Math.round(r.width),
+      h: Math.round(r.height),
+      href: anchors[i].href
+    });
+  }
+  return out;
+})()
+`
+
+// renderBrowser, her istekte yeni bir chromedp.NewExecAllocator açmak yerine
+// tek bir tarayıcı süreci üzerinde paylaşılan bir allocator tutar; yük altında
+// process başına ~saniyelik başlatma maliyetinden kaçınmak için.
+type renderBrowser struct {
+	once     sync.Once
+	allocCtx context.Context
+	cancel   context.CancelFunc
+}
+
+var sharedBrowser renderBrowser
+
+func (b *renderBrowser) ensure() context.Context {
+	b.once.Do(func() {
+		allocCtx, cancel := chromedp.NewExecAllocator(context.Background(),
+			append(chromedp.DefaultExecAllocatorOptions[:],
+				chromedp.Flag("headless", true),
+				chromedp.Flag("disable-gpu", true),
+				chromedp.Flag("no-sandbox", true),
+				chromedp.Flag("disable-dev-shm-usage", true),
+			)...,
+		)
+		b.allocCtx = allocCtx
+		b.cancel = cancel
+	})
+	return b.allocCtx
+}
+
+// runServeMode, -serve bayrağı verildiğinde main()'den çağrılır ve proxy
+// HTTP sunucusunu ön planda (bloklayarak) çalıştırır.
+func runServeMode(addr string, perRequestTimeout time.Duration) {
+	sharedBrowser.ensure()
+	defer sharedBrowser.cancel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", renderProxyHandler(perRequestTimeout))
+
+	fmt.Printf("[*] Render proxy açık: http://localhost%s/?url=https://example.com\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("[-] render proxy kapandı: %v", err)
+	}
+}
+
+func renderProxyHandler(perRequestTimeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := r.URL.Query().Get("url")
+		if target == "" {
+			http.Error(w, "?url= parametresi gerekli", http.StatusBadRequest)
+			return
+		}
+
+		page := queryInt(r, "p", 1)
+		width := queryInt(r, "w", 1024)
+		height := queryInt(r, "h", 768)
+		colors := queryInt(r, "c", 256)
+		if page < 1 {
+			page = 1
+		}
+		if colors < 2 {
+			colors = 2
+		}
+		if colors > 256 {
+			colors = 256
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), perRequestTimeout)
+		defer cancel()
+
+		gifBytes, anchors, err := renderPageAsGIF(ctx, target, page, width, height, colors)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("render hatası: %v", err), http.StatusBadGateway)
+			return
+		}
+
+		html := buildImageMapHTML(target, page, width, height, colors, gifBytes, anchors)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(html))
+	}
+}
+
+// renderPageAsGIF, paylaşılan tarayıcı üzerinde yeni bir tab açar, hedef
+// sayfayı yükler, istenen sayfaya (p) kaydırır, viewport'un ekran görüntüsünü
+// alır ve anchor konumlarını toplar. PNG olarak gelen görüntü, go-quantize ile
+// istenen renk sayısına (c) indirgenip GIF'e çevrilir.
+func renderPageAsGIF(ctx context.Context, target string, page, width, height, colorCount int) ([]byte, []renderedAnchor, error) {
+	tabCtx, tabCancel := chromedp.NewContext(sharedBrowser.ensure())
+	defer tabCancel()
+
+	// İsteğin zaman aşımını yeni tab'a da taşı.
+	if deadline, ok := ctx.Deadline(); ok {
+		var deadlineCancel context.CancelFunc
+		tabCtx, deadlineCancel = context.WithDeadline(tabCtx, deadline)
+		defer deadlineCancel()
+	}
+
+	var anchorsRaw []map[string]any
+	var pngBuf []byte
+
+	scrollY := (page - 1) * height
+	tasks := chromedp.Tasks{
+		chromedp.EmulateViewport(int64(width), int64(height)),
+		chromedp.Navigate(target),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+		chromedp.Evaluate(fmt.Sprintf("window.scrollTo(0, %d)", scrollY), nil),
+		chromedp.Sleep(150 * time.Millisecond),
+		chromedp.Evaluate(collectAnchorsJS, &anchorsRaw),
+		chromedp.CaptureScreenshot(&pngBuf),
+	}
+
+	if err := chromedp.Run(tabCtx, tasks); err != nil {
+		return nil, nil, err
+	}
+
+	anchors := make([]renderedAnchor, 0, len(anchorsRaw))
+	for _, a := range anchorsRaw {
+		anchors = append(anchors, renderedAnchor{
+			X:    toInt(a["x"]),
+			Y:    toInt(a["y"]),
+			W:    toInt(a["w"]),
+			H:    toInt(a["h"]),
+			Href: fmt.Sprintf("%v", a["href"]),
+		})
+	}
+
+	img, err := png.Decode(bytes.NewReader(pngBuf))
+	if err != nil {
+		return nil, nil, fmt.Errorf("screenshot PNG çözülemedi: %v", err)
+	}
+
+	q := quantize.MedianCutQuantizer{}
+	palette := q.Quantize(make(color.Palette, 0, colorCount), img)
+
+	paletted := image.NewPaletted(img.Bounds(), palette)
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			paletted.Set(x, y, img.At(x, y))
+		}
+	}
+
+	var gifBuf bytes.Buffer
+	if err := gif.Encode(&gifBuf, paletted, &gif.Options{NumColors: colorCount}); err != nil {
+		return nil, nil, fmt.Errorf("GIF kodlanamadı: %v", err)
+	}
+
+	return gifBuf.Bytes(), anchors, nil
+}
+
+// buildImageMapHTML, render edilen GIF'i ve toplanan anchor konumlarını bir
+// <img usemap> + <map><area>...</map> olarak birleştirir. Her <area>, tekrar
+// bu proxy'ye (hedef href ile) dönen bir link taşır.
+func buildImageMapHTML(sourceURL string, page, width, height, colors int, gifBytes []byte, anchors []renderedAnchor) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s (sayfa %d)</title></head><body style=\"margin:0\">\n", htmlEscape(sourceURL), page)
+
+	b.WriteString(`<img src="data:image/gif;base64,`)
+	b.WriteString(base64.StdEncoding.EncodeToString(gifBytes))
+	b.WriteString(`" usemap="#pagemap" width="`)
+	fmt.Fprintf(&b, "%d\" height=\"%d\">\n", width, height)
+
+	b.WriteString(`<map name="pagemap">` + "\n")
+	for _, a := range anchors {
+		proxied := proxyURLFor(a.Href, 1, width, height, colors)
+		fmt.Fprintf(&b, "<area shape=\"rect\" coords=\"%d,%d,%d,%d\" href=\"%s\">\n",
+			a.X, a.Y, a.X+a.W, a.Y+a.H, htmlEscape(proxied))
+	}
+	b.WriteString("</map>\n")
+
+	if page > 1 {
+		fmt.Fprintf(&b, "<a href=\"%s\">&laquo; önceki sayfa</a> \n", htmlEscape(proxyURLFor(sourceURL, page-1, width, height, colors)))
+	}
+	fmt.Fprintf(&b, "<a href=\"%s\">sonraki sayfa &raquo;</a>\n", htmlEscape(proxyURLFor(sourceURL, page+1, width, height, colors)))
+
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
+
+func proxyURLFor(target string, page, width, height, colors int) string {
+	v := url.Values{}
+	v.Set("url", target)
+	v.Set("p", strconv.Itoa(page))
+	v.Set("w", strconv.Itoa(width))
+	v.Set("h", strconv.Itoa(height))
+	v.Set("c", strconv.Itoa(colors))
+	return "/?" + v.Encode()
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	s := r.URL.Query().Get(key)
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func toInt(v any) int {
+	f, _ := v.(float64)
+	return int(f)
+}
+
+func htmlEscape(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	return r.Replace(s)
+}
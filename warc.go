@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// -archive-format warc, archive.go'nun dosya tabanlı assets/ snapshot'ı
+// yerine tek bir WARC 1.1 dosyası üretir: ana sayfa ve her asset için bir
+// "request" + "response" kaydı çifti, pywb/wayback gibi replay araçlarının
+// beklediği WARC-Type/WARC-Target-URI/Content-Length başlıklarıyla.
+// İndirilemeyen (bağlantı hatası veren) asset'ler için kayıt yazılmaz.
+func archiveAsWARC(baseURL *url.URL, body []byte, outDir string, opts siteOptions) error {
+	archiveDir := filepath.Join(outDir, "archive")
+	if err := os.MkdirAll(archiveDir, 0o755); err != nil {
+		return fmt.Errorf("archive klasörü oluşturulamadı: %v", err)
+	}
+
+	f, err := os.Create(filepath.Join(archiveDir, "archive.warc"))
+	if err != nil {
+		return fmt.Errorf("archive.warc oluşturulamadı: %v", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+
+	if err := writeWARCInfo(w); err != nil {
+		return err
+	}
+	if err := writeWARCPair(w, baseURL.String(), "text/html; charset=utf-8", 200, body); err != nil {
+		return err
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("HTML parse edilemedi: %v", err)
+	}
+
+	limiter := newHostRateLimiter(opts.RateLimitDelay)
+	seen := make(map[string]bool)
+	for _, ref := range collectAssetRefs(doc, baseURL) {
+		if seen[ref.absoluteURL] {
+			continue
+		}
+		seen[ref.absoluteURL] = true
+
+		limiter.wait(ref.absoluteURL)
+		assetBody, contentType, status, err := fetchRawBytes(ref.absoluteURL)
+		if err != nil {
+			continue
+		}
+		if err := writeWARCPair(w, ref.absoluteURL, contentType, status, assetBody); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func fetchRawBytes(target string) ([]byte, string, int, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	req.Header.Set("User-Agent", "webscraper-go/1.0 (+https://example.com)")
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer resp.Body.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, "", 0, err
+	}
+	return buf.Bytes(), resp.Header.Get("Content-Type"), resp.StatusCode, nil
+}
+
+func writeWARCInfo(w *bufio.Writer) error {
+	payload := []byte("software: webscraper-go\r\nformat: WARC File Format 1.1\r\n")
+	return writeWARCRecord(w, "warcinfo", "", "application/warc-fields", payload)
+}
+
+// writeWARCPair, tek bir URL için sentetik bir "request" kaydı ve gerçek
+// içeriği taşıyan bir "response" kaydı yazar.
+func writeWARCPair(w *bufio.Writer, targetURI, contentType string, status int, body []byte) error {
+	reqPayload := []byte(fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nUser-Agent: webscraper-go/1.0\r\n\r\n",
+		targetURI, hostOf(targetURI)))
+	if err := writeWARCRecord(w, "request", targetURI, "application/http; msgtype=request", reqPayload); err != nil {
+		return err
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	statusText := http.StatusText(status)
+	if statusText == "" {
+		statusText = "OK"
+	}
+	respHeader := fmt.Sprintf("HTTP/1.1 %d %s\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n",
+		status, statusText, contentType, len(body))
+	respPayload := append([]byte(respHeader), body...)
+
+	return writeWARCRecord(w, "response", targetURI, "application/http; msgtype=response", respPayload)
+}
+
+func writeWARCRecord(w *bufio.Writer, warcType, targetURI, contentType string, payload []byte) error {
+	fmt.Fprintf(w, "WARC/1.1\r\n")
+	fmt.Fprintf(w, "WARC-Type: %s\r\n", warcType)
+	if targetURI != "" {
+		fmt.Fprintf(w, "WARC-Target-URI: %s\r\n", targetURI)
+	}
+	fmt.Fprintf(w, "WARC-Date: %s\r\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "WARC-Record-ID: <urn:uuid:%s>\r\n", warcRecordID(warcType+targetURI))
+	fmt.Fprintf(w, "Content-Type: %s\r\n", contentType)
+	fmt.Fprintf(w, "Content-Length: %d\r\n", len(payload))
+	fmt.Fprintf(w, "\r\n")
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\r\n\r\n")
+	return nil
+}
+
+// warcRecordID, WARC-Record-ID için gerçek bir rastgele UUID yerine, seed'e
+// (kayıt tipi + URI + zaman) göre türetilmiş, UUID'ye benzeyen tekil bir
+// kimlik üretir.
+func warcRecordID(seed string) string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s|%d", seed, time.Now().UnixNano())))
+	s := hex.EncodeToString(h[:16])
+	return fmt.Sprintf("%s-%s-%s-%s-%s", s[0:8], s[8:12], s[12:16], s[16:20], s[20:32])
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}